@@ -172,6 +172,7 @@ type MigrationContext struct {
 	HasSuperPrivilege                      bool
 	OriginalBinlogFormat                   string
 	OriginalBinlogRowImage                 string
+	CaseSensitiveTableNames                bool
 	InspectorConnectionConfig              *mysql.ConnectionConfig
 	InspectorMySQLVersion                  string
 	ApplierConnectionConfig                *mysql.ConnectionConfig
@@ -233,7 +234,11 @@ type MigrationContext struct {
 
 	recentBinlogCoordinates mysql.BinlogCoordinates
 
-	BinlogSyncerMaxReconnectAttempts int
+	BinlogSyncerMaxReconnectAttempts   int
+	BinlogSyncerHeartbeatPeriodSeconds int64
+	BinlogSyncerReadTimeoutSeconds     int64
+
+	ChangelogStateWarningIntervalSeconds int64
 
 	Log Logger
 }
@@ -443,6 +448,31 @@ func (this *MigrationContext) MaxRetries() int64 {
 	return retries
 }
 
+// RetryWithExponentialBackoff attempts running given function, calling sleep for 2^(n-1)
+// seconds between each attempt, where `n` is the running number of attempts. Exits
+// as soon as the function returns with non-error, or as soon as `MaxRetries`
+// attempts are reached, returning the last error. Wait intervals between attempts obey
+// a maximum of `ExponentialBackoffMaxInterval`.
+func (this *MigrationContext) RetryWithExponentialBackoff(operation func() error, sleep func(time.Duration)) (err error) {
+	var interval int64
+	maxRetries := int(this.MaxRetries())
+	maxInterval := this.ExponentialBackoffMaxInterval
+	for i := 0; i < maxRetries; i++ {
+		newInterval := int64(math.Exp2(float64(i - 1)))
+		if newInterval <= maxInterval {
+			interval = newInterval
+		}
+		if i != 0 {
+			sleep(time.Duration(interval) * time.Second)
+		}
+		err = operation()
+		if err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
 func (this *MigrationContext) IsTransactionalTable() bool {
 	switch strings.ToLower(this.TableEngine) {
 	case "innodb":
@@ -6,6 +6,7 @@
 package base
 
 import (
+	"errors"
 	"os"
 	"testing"
 	"time"
@@ -119,3 +120,38 @@ func TestReadConfigFile(t *testing.T) {
 		}
 	}
 }
+
+func TestRetryWithExponentialBackoff(t *testing.T) {
+	{
+		context := NewMigrationContext()
+		context.SetDefaultNumRetries(5)
+		context.SetExponentialBackoffMaxInterval(4)
+		slept := []time.Duration{}
+		attempts := 0
+		err := context.RetryWithExponentialBackoff(func() error {
+			attempts++
+			return errors.New("failed")
+		}, func(d time.Duration) { slept = append(slept, d) })
+		test.S(t).ExpectNotNil(err)
+		test.S(t).ExpectEquals(attempts, 5)
+		test.S(t).ExpectEquals(len(slept), 4)
+		test.S(t).ExpectEquals(slept[0], 1*time.Second)
+		test.S(t).ExpectEquals(slept[1], 2*time.Second)
+		test.S(t).ExpectEquals(slept[2], 4*time.Second)
+		test.S(t).ExpectEquals(slept[3], 4*time.Second)
+	}
+	{
+		context := NewMigrationContext()
+		context.SetDefaultNumRetries(5)
+		attempts := 0
+		err := context.RetryWithExponentialBackoff(func() error {
+			attempts++
+			if attempts < 3 {
+				return errors.New("failed")
+			}
+			return nil
+		}, func(time.Duration) {})
+		test.S(t).ExpectNil(err)
+		test.S(t).ExpectEquals(attempts, 3)
+	}
+}
@@ -44,6 +44,16 @@ func ToEventDML(description string) EventDML {
 	return NotDML
 }
 
+// UnknownDMLTypeError is returned when a rows event of an unrecognized type is encountered.
+// EventType is the raw event type string, e.g. as reported by replication.EventType.String()
+type UnknownDMLTypeError struct {
+	EventType string
+}
+
+func (this *UnknownDMLTypeError) Error() string {
+	return fmt.Sprintf("Unknown DML type: %s", this.EventType)
+}
+
 // BinlogDMLEvent is a binary log rows (DML) event entry, with data
 type BinlogDMLEvent struct {
 	DatabaseName      string
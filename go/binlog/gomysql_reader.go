@@ -7,6 +7,8 @@ package binlog
 
 import (
 	"fmt"
+	"math"
+	"strings"
 	"sync"
 
 	"github.com/github/gh-ost/go/base"
@@ -37,38 +39,78 @@ func NewGoMySQLReader(migrationContext *base.MigrationContext) *GoMySQLReader {
 		connectionConfig:        connectionConfig,
 		currentCoordinates:      mysql.BinlogCoordinates{},
 		currentCoordinatesMutex: &sync.Mutex{},
-		binlogSyncer: replication.NewBinlogSyncer(replication.BinlogSyncerConfig{
-			ServerID:                uint32(migrationContext.ReplicaServerId),
-			Flavor:                  gomysql.MySQLFlavor,
-			Host:                    connectionConfig.Key.Hostname,
-			Port:                    uint16(connectionConfig.Key.Port),
-			User:                    connectionConfig.User,
-			Password:                connectionConfig.Password,
-			TLSConfig:               connectionConfig.TLSConfig(),
-			UseDecimal:              true,
-			MaxReconnectAttempts:    migrationContext.BinlogSyncerMaxReconnectAttempts,
-			TimestampStringLocation: time.UTC,
-		}),
+		binlogSyncer:            replication.NewBinlogSyncer(newBinlogSyncerConfig(migrationContext)),
 	}
 }
 
-// ConnectBinlogStreamer
+// newBinlogSyncerConfig returns the configuration by which we register as a replica on the inspected server
+func newBinlogSyncerConfig(migrationContext *base.MigrationContext) replication.BinlogSyncerConfig {
+	connectionConfig := migrationContext.InspectorConnectionConfig
+	return replication.BinlogSyncerConfig{
+		ServerID:                uint32(migrationContext.ReplicaServerId),
+		Flavor:                  binlogSyncerFlavor(migrationContext.InspectorMySQLVersion),
+		Host:                    connectionConfig.Key.Hostname,
+		Port:                    uint16(connectionConfig.Key.Port),
+		User:                    connectionConfig.User,
+		Password:                connectionConfig.Password,
+		TLSConfig:               connectionConfig.TLSConfig(),
+		UseDecimal:              true,
+		MaxReconnectAttempts:    migrationContext.BinlogSyncerMaxReconnectAttempts,
+		HeartbeatPeriod:         time.Duration(migrationContext.BinlogSyncerHeartbeatPeriodSeconds) * time.Second,
+		ReadTimeout:             time.Duration(migrationContext.BinlogSyncerReadTimeoutSeconds) * time.Second,
+		TimestampStringLocation: time.UTC,
+	}
+}
+
+// binlogSyncerFlavor returns the go-mysql flavor to use when registering with a server
+// reporting the given @@version. MariaDB uses its own binlog dump protocol.
+func binlogSyncerFlavor(mysqlVersion string) string {
+	if strings.Contains(strings.ToLower(mysqlVersion), "mariadb") {
+		return gomysql.MariaDBFlavor
+	}
+	return gomysql.MySQLFlavor
+}
+
+// ConnectBinlogStreamer starts syncing at given coordinates, which are expected to have been
+// checked by ValidateCoordinates
 func (this *GoMySQLReader) ConnectBinlogStreamer(coordinates mysql.BinlogCoordinates) (err error) {
-	if coordinates.IsEmpty() {
-		return this.migrationContext.Log.Errorf("Empty coordinates at ConnectBinlogStreamer()")
+	position, err := toGoMySQLPosition(coordinates)
+	if err != nil {
+		return this.migrationContext.Log.Errore(err)
 	}
 
 	this.currentCoordinates = coordinates
 	this.migrationContext.Log.Infof("Connecting binlog streamer at %+v", this.currentCoordinates)
 	// Start sync with specified binlog file and position
-	this.binlogStreamer, err = this.binlogSyncer.StartSync(gomysql.Position{
-		Name: this.currentCoordinates.LogFile,
-		Pos:  uint32(this.currentCoordinates.LogPos),
-	})
+	this.binlogStreamer, err = this.binlogSyncer.StartSync(position)
 
 	return err
 }
 
+// toGoMySQLPosition converts coordinates to the position go-mysql syncs from. Binlog positions
+// are 4 bytes wide; rather than silently truncate a larger LogPos (and resume at the wrong
+// place) we return an error.
+func toGoMySQLPosition(coordinates mysql.BinlogCoordinates) (gomysql.Position, error) {
+	if coordinates.LogPos > math.MaxUint32 {
+		return gomysql.Position{}, fmt.Errorf("Binlog position %+v overflows 4 bytes", coordinates)
+	}
+	return gomysql.Position{
+		Name: coordinates.LogFile,
+		Pos:  uint32(coordinates.LogPos),
+	}, nil
+}
+
+// ValidateCoordinates returns an error if the binlog streamer cannot be connected at the given
+// coordinates: they are malformed, or their position does not fit in 4 bytes. Such errors are
+// permanent: unlike connection failures, retrying won't help.
+func ValidateCoordinates(coordinates mysql.BinlogCoordinates) error {
+	if err := coordinates.Validate(); err != nil {
+		return err
+	}
+	_, err := toGoMySQLPosition(coordinates)
+	return err
+}
+
 func (this *GoMySQLReader) GetCurrentBinlogCoordinates() *mysql.BinlogCoordinates {
 	this.currentCoordinatesMutex.Lock()
 	defer this.currentCoordinatesMutex.Unlock()
@@ -76,6 +118,19 @@ func (this *GoMySQLReader) GetCurrentBinlogCoordinates() *mysql.BinlogCoordinate
 	return &returnCoordinates
 }
 
+// handleRotateEvent moves the current coordinates to the start of the next log
+func (this *GoMySQLReader) handleRotateEvent(rotateEvent *replication.RotateEvent) {
+	var previousCoordinates mysql.BinlogCoordinates
+	func() {
+		this.currentCoordinatesMutex.Lock()
+		defer this.currentCoordinatesMutex.Unlock()
+		previousCoordinates = this.currentCoordinates
+		this.currentCoordinates.LogFile = string(rotateEvent.NextLogName)
+		this.currentCoordinates.LogPos = int64(rotateEvent.Position)
+	}()
+	this.migrationContext.Log.Infof("rotate to next log from %s:%d to %s:%d", previousCoordinates.LogFile, previousCoordinates.LogPos, rotateEvent.NextLogName, rotateEvent.Position)
+}
+
 // StreamEvents
 func (this *GoMySQLReader) handleRowsEvent(ev *replication.BinlogEvent, rowsEvent *replication.RowsEvent, entriesChannel chan<- *BinlogEntry) error {
 	if this.currentCoordinates.IsLogPosOverflowBeyond4Bytes(&this.LastAppliedRowsEventHint) {
@@ -89,7 +144,11 @@ func (this *GoMySQLReader) handleRowsEvent(ev *replication.BinlogEvent, rowsEven
 
 	dml := ToEventDML(ev.Header.EventType.String())
 	if dml == NotDML {
-		return fmt.Errorf("Unknown DML type: %s", ev.Header.EventType.String())
+		return &UnknownDMLTypeError{EventType: ev.Header.EventType.String()}
+	}
+	if dml == UpdateDML && len(rowsEvent.Rows)%2 != 0 {
+		// An update has two rows (WHERE+SET) per changed row
+		return fmt.Errorf("Unexpected odd number of rows (%d) in update rows event at %+v", len(rowsEvent.Rows), this.currentCoordinates)
 	}
 	for i, row := range rowsEvent.Rows {
 		if dml == UpdateDML && i%2 == 1 {
@@ -150,12 +209,7 @@ func (this *GoMySQLReader) StreamEvents(canStopStreaming func() bool, entriesCha
 
 		switch binlogEvent := ev.Event.(type) {
 		case *replication.RotateEvent:
-			func() {
-				this.currentCoordinatesMutex.Lock()
-				defer this.currentCoordinatesMutex.Unlock()
-				this.currentCoordinates.LogFile = string(binlogEvent.NextLogName)
-			}()
-			this.migrationContext.Log.Infof("rotate to next log from %s:%d to %s", this.currentCoordinates.LogFile, int64(ev.Header.LogPos), binlogEvent.NextLogName)
+			this.handleRotateEvent(binlogEvent)
 		case *replication.RowsEvent:
 			if err := this.handleRowsEvent(ev, binlogEvent, entriesChannel); err != nil {
 				return err
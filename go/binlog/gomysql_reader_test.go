@@ -0,0 +1,169 @@
+/*
+   Copyright 2022 GitHub Inc.
+	 See https://github.com/github/gh-ost/blob/master/LICENSE
+*/
+
+package binlog
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/github/gh-ost/go/base"
+	"github.com/github/gh-ost/go/mysql"
+
+	gomysql "github.com/go-mysql-org/go-mysql/mysql"
+	"github.com/go-mysql-org/go-mysql/replication"
+	"github.com/openark/golib/log"
+	test "github.com/openark/golib/tests"
+)
+
+func init() {
+	log.SetLevel(log.ERROR)
+}
+
+func TestBinlogSyncerFlavor(t *testing.T) {
+	test.S(t).ExpectEquals(binlogSyncerFlavor(""), gomysql.MySQLFlavor)
+	test.S(t).ExpectEquals(binlogSyncerFlavor("8.0.36"), gomysql.MySQLFlavor)
+	test.S(t).ExpectEquals(binlogSyncerFlavor("5.7.44-log"), gomysql.MySQLFlavor)
+	test.S(t).ExpectEquals(binlogSyncerFlavor("10.6.16-MariaDB-log"), gomysql.MariaDBFlavor)
+	test.S(t).ExpectEquals(binlogSyncerFlavor("10.11.6-mariadb"), gomysql.MariaDBFlavor)
+}
+
+func TestNewBinlogSyncerConfig(t *testing.T) {
+	migrationContext := base.NewMigrationContext()
+	migrationContext.ReplicaServerId = 99999
+	migrationContext.InspectorConnectionConfig.Key.Hostname = "replica.example.com"
+	migrationContext.InspectorConnectionConfig.Key.Port = 3306
+	migrationContext.BinlogSyncerHeartbeatPeriodSeconds = 30
+	migrationContext.BinlogSyncerReadTimeoutSeconds = 60
+
+	config := newBinlogSyncerConfig(migrationContext)
+	test.S(t).ExpectEquals(config.ServerID, uint32(99999))
+	test.S(t).ExpectEquals(config.Flavor, gomysql.MySQLFlavor)
+	test.S(t).ExpectEquals(config.Host, "replica.example.com")
+	test.S(t).ExpectEquals(config.Port, uint16(3306))
+	test.S(t).ExpectEquals(config.HeartbeatPeriod, 30*time.Second)
+	test.S(t).ExpectEquals(config.ReadTimeout, 60*time.Second)
+
+	// the read timeout is opt-in: unset, go-mysql must not put a deadline on binlog reads,
+	// which would otherwise expire while gh-ost is throttled and not consuming events
+	config = newBinlogSyncerConfig(base.NewMigrationContext())
+	test.S(t).ExpectEquals(config.ReadTimeout, time.Duration(0))
+}
+
+func TestToGoMySQLPosition(t *testing.T) {
+	{
+		position, err := toGoMySQLPosition(mysql.BinlogCoordinates{LogFile: "mysql-bin.000017", LogPos: 104})
+		test.S(t).ExpectNil(err)
+		test.S(t).ExpectEquals(position.Name, "mysql-bin.000017")
+		test.S(t).ExpectEquals(position.Pos, uint32(104))
+	}
+	{
+		position, err := toGoMySQLPosition(mysql.BinlogCoordinates{LogFile: "mysql-bin.000017", LogPos: math.MaxUint32})
+		test.S(t).ExpectNil(err)
+		test.S(t).ExpectEquals(position.Pos, uint32(math.MaxUint32))
+	}
+	{
+		_, err := toGoMySQLPosition(mysql.BinlogCoordinates{LogFile: "mysql-bin.000017", LogPos: math.MaxUint32 + 1})
+		test.S(t).ExpectNotNil(err)
+	}
+	{
+		_, err := toGoMySQLPosition(mysql.BinlogCoordinates{LogFile: "mysql-bin.000017", LogPos: math.MaxUint32 + 104})
+		test.S(t).ExpectNotNil(err)
+	}
+}
+
+func TestValidateCoordinates(t *testing.T) {
+	test.S(t).ExpectNil(ValidateCoordinates(mysql.BinlogCoordinates{LogFile: "mysql-bin.000017", LogPos: 104}))
+	test.S(t).ExpectNotNil(ValidateCoordinates(mysql.BinlogCoordinates{LogFile: "", LogPos: 104}))
+	test.S(t).ExpectNotNil(ValidateCoordinates(mysql.BinlogCoordinates{LogFile: "mysql-bin", LogPos: 104}))
+	test.S(t).ExpectNotNil(ValidateCoordinates(mysql.BinlogCoordinates{LogFile: "mysql-bin.000017", LogPos: -1}))
+	test.S(t).ExpectNotNil(ValidateCoordinates(mysql.BinlogCoordinates{LogFile: "mysql-bin.000017", LogPos: math.MaxUint32 + 1}))
+}
+
+// infoCapturingLogger records the messages logged via Infof
+type infoCapturingLogger struct {
+	base.Logger
+	infos []string
+}
+
+func (this *infoCapturingLogger) Infof(format string, args ...interface{}) {
+	this.infos = append(this.infos, fmt.Sprintf(format, args...))
+}
+
+func TestHandleRotateEvent(t *testing.T) {
+	logger := &infoCapturingLogger{Logger: base.NewDefaultLogger()}
+	migrationContext := base.NewMigrationContext()
+	migrationContext.ReplicaServerId = 99999
+	migrationContext.Log = logger
+	reader := NewGoMySQLReader(migrationContext)
+	defer reader.Close()
+	reader.currentCoordinates = mysql.BinlogCoordinates{LogFile: "mysql-bin.000017", LogPos: 1073741824, EventSize: 47}
+
+	reader.handleRotateEvent(&replication.RotateEvent{Position: 4, NextLogName: []byte("mysql-bin.000018")})
+
+	coordinates := reader.GetCurrentBinlogCoordinates()
+	test.S(t).ExpectEquals(coordinates.LogFile, "mysql-bin.000018")
+	test.S(t).ExpectEquals(coordinates.LogPos, int64(4))
+	test.S(t).ExpectEquals(len(logger.infos), 1)
+	test.S(t).ExpectEquals(logger.infos[0], "rotate to next log from mysql-bin.000017:1073741824 to mysql-bin.000018:4")
+}
+
+func TestHandleRowsEvent(t *testing.T) {
+	handleRowsEvent := func(eventType replication.EventType, rows [][]interface{}) ([]*BinlogEntry, error) {
+		migrationContext := base.NewMigrationContext()
+		migrationContext.ReplicaServerId = 99999
+		reader := NewGoMySQLReader(migrationContext)
+		defer reader.Close()
+		reader.currentCoordinates = mysql.BinlogCoordinates{LogFile: "mysql-bin.000017", LogPos: 1024}
+		ev := &replication.BinlogEvent{
+			Header: &replication.EventHeader{EventType: eventType},
+		}
+		rowsEvent := &replication.RowsEvent{
+			Table: &replication.TableMapEvent{Schema: []byte("test"), Table: []byte("orders")},
+			Rows:  rows,
+		}
+		entriesChannel := make(chan *BinlogEntry, len(rows))
+		err := reader.handleRowsEvent(ev, rowsEvent, entriesChannel)
+		close(entriesChannel)
+		entries := []*BinlogEntry{}
+		for entry := range entriesChannel {
+			entries = append(entries, entry)
+		}
+		return entries, err
+	}
+
+	// PARTIAL_UPDATE_ROWS_EVENT, which the vendored go-mysql does not know about
+	partialUpdateRowsEvent := replication.EventType(39)
+
+	cases := []struct {
+		name                 string
+		eventType            replication.EventType
+		rows                 [][]interface{}
+		expectedEntries      int
+		expectErr            bool
+		expectUnknownDMLType bool
+	}{
+		{name: "insert", eventType: replication.WRITE_ROWS_EVENTv2, rows: [][]interface{}{{1, "a"}, {2, "b"}}, expectedEntries: 2},
+		{name: "update pairs", eventType: replication.UPDATE_ROWS_EVENTv2, rows: [][]interface{}{{1, "a"}, {1, "b"}}, expectedEntries: 1},
+		{name: "odd update rows", eventType: replication.UPDATE_ROWS_EVENTv2, rows: [][]interface{}{{1, "a"}, {1, "b"}, {2, "c"}}, expectErr: true},
+		{name: "unknown DML type", eventType: partialUpdateRowsEvent, rows: [][]interface{}{{1, "a"}}, expectErr: true, expectUnknownDMLType: true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			entries, err := handleRowsEvent(c.eventType, c.rows)
+			test.S(t).ExpectEquals(err != nil, c.expectErr)
+			test.S(t).ExpectEquals(len(entries), c.expectedEntries)
+
+			var unknownDMLTypeErr *UnknownDMLTypeError
+			test.S(t).ExpectEquals(errors.As(err, &unknownDMLTypeErr), c.expectUnknownDMLType)
+			if c.expectUnknownDMLType {
+				test.S(t).ExpectEquals(unknownDMLTypeErr.EventType, "UnknownEvent")
+			}
+		})
+	}
+}
@@ -136,6 +136,9 @@ func main() {
 
 	flag.UintVar(&migrationContext.ReplicaServerId, "replica-server-id", 99999, "server id used by gh-ost process. Default: 99999")
 	flag.IntVar(&migrationContext.BinlogSyncerMaxReconnectAttempts, "binlogsyncer-max-reconnect-attempts", 0, "when master node fails, the maximum number of binlog synchronization attempts to reconnect. 0 is unlimited")
+	flag.Int64Var(&migrationContext.BinlogSyncerHeartbeatPeriodSeconds, "binlogsyncer-heartbeat-period-seconds", 30, "interval at which the server sends heartbeat events to gh-ost's binlog connection when there are no other events. 0 disables server heartbeats")
+	flag.Int64Var(&migrationContext.BinlogSyncerReadTimeoutSeconds, "binlogsyncer-read-timeout-seconds", 0, "number of seconds without any binlog event after which the binlog connection is considered dead and is reconnected. Must be greater than --binlogsyncer-heartbeat-period-seconds, and than the longest expected throttle. 0 (default) disables the timeout")
+	flag.Int64Var(&migrationContext.ChangelogStateWarningIntervalSeconds, "changelog-state-warning-interval-seconds", 60, "while waiting at startup for the changelog table state to appear in the binary logs, warn at this interval about what is being waited for. 0 disables the warning")
 
 	maxLoad := flag.String("max-load", "", "Comma delimited status-name=threshold. e.g: 'Threads_running=100,Threads_connected=500'. When status exceeds threshold, app throttles writes")
 	criticalLoad := flag.String("critical-load", "", "Comma delimited status-name=threshold, same format as --max-load. When status exceeds threshold, app panics and quits")
@@ -256,6 +259,9 @@ func main() {
 	if migrationContext.TLSAllowInsecure && !migrationContext.UseTLS {
 		migrationContext.Log.Fatal("--ssl-allow-insecure requires --ssl")
 	}
+	if migrationContext.BinlogSyncerReadTimeoutSeconds > 0 && migrationContext.BinlogSyncerReadTimeoutSeconds <= migrationContext.BinlogSyncerHeartbeatPeriodSeconds {
+		migrationContext.Log.Fatal("--binlogsyncer-read-timeout-seconds must be greater than --binlogsyncer-heartbeat-period-seconds")
+	}
 	if *replicationLagQuery != "" {
 		migrationContext.Log.Warning("--replication-lag-query is deprecated")
 	}
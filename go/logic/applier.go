@@ -115,6 +115,9 @@ func (this *Applier) validateAndReadGlobalVariables() error {
 	); err != nil {
 		return err
 	}
+	if err := validateBinlogTransactionCompression(this.db, this.connectionConfig.Key); err != nil {
+		return err
+	}
 
 	this.migrationContext.Log.Infof("will use time_zone='%s' on applier", this.migrationContext.ApplierTimeZone)
 	return nil
@@ -69,6 +69,12 @@ func (this *Inspector) InitDBConnections() (err error) {
 	if err := this.validateBinlogs(); err != nil {
 		return err
 	}
+	if err := this.validateReplicaServerId(); err != nil {
+		return err
+	}
+	if err := this.readLowerCaseTableNames(); err != nil {
+		return err
+	}
 	if err := this.applyBinlogFormat(); err != nil {
 		return err
 	}
@@ -372,11 +378,91 @@ func (this *Inspector) validateBinlogs() error {
 	if this.migrationContext.OriginalBinlogRowImage != "FULL" {
 		return fmt.Errorf("%s has '%s' binlog_row_image, and only 'FULL' is supported. This operation cannot proceed. You may `set global binlog_row_image='full'` and try again", this.connectionConfig.Key.String(), this.migrationContext.OriginalBinlogRowImage)
 	}
+	// binlog_row_value_options only exists as of MySQL 8.0
+	binlogRowValueOptions, err := mysql.GetGlobalVariable(this.db, "binlog_row_value_options")
+	if err != nil {
+		return err
+	}
+	if strings.Contains(strings.ToUpper(binlogRowValueOptions), "PARTIAL_JSON") {
+		return fmt.Errorf("%s has '%s' binlog_row_value_options; partial JSON updates are not supported. This operation cannot proceed. You may `set global binlog_row_value_options=''` and try again", this.connectionConfig.Key.String(), binlogRowValueOptions)
+	}
+	if err := validateBinlogTransactionCompression(this.db, this.connectionConfig.Key); err != nil {
+		return err
+	}
 
 	this.migrationContext.Log.Infof("binary logs validated on %s", this.connectionConfig.Key.String())
 	return nil
 }
 
+// validateReplicaServerId checks that the server id by which the binlog streamer registers as a
+// replica is not already in use by the inspected server or by any of its connected replicas.
+func (this *Inspector) validateReplicaServerId() error {
+	var inspectedServerId uint
+	query := `select /* gh-ost */ @@global.server_id`
+	if err := this.db.QueryRow(query).Scan(&inspectedServerId); err != nil {
+		return err
+	}
+	replicaServerIds := []uint{}
+	query = `show /* gh-ost */ slave hosts`
+	err := sqlutils.QueryRowsMap(this.db, query, func(rowMap sqlutils.RowMap) error {
+		replicaServerIds = append(replicaServerIds, rowMap.GetUint("Server_id"))
+		return nil
+	})
+	if err != nil {
+		// not all managed services allow listing replicas; we can still check the server itself
+		this.migrationContext.Log.Warningf("Unable to list replicas of %s to validate --replica-server-id: %+v", this.connectionConfig.Key.String(), err)
+	}
+	return this.checkReplicaServerIdCollision(inspectedServerId, replicaServerIds)
+}
+
+func (this *Inspector) checkReplicaServerIdCollision(inspectedServerId uint, replicaServerIds []uint) error {
+	serverId := this.migrationContext.ReplicaServerId
+	suggestedServerId := serverId
+	collision := false
+	for _, replicaServerId := range append(replicaServerIds, inspectedServerId) {
+		if replicaServerId == serverId {
+			collision = true
+		}
+		if replicaServerId >= suggestedServerId {
+			suggestedServerId = replicaServerId + 1
+		}
+	}
+	if collision {
+		return fmt.Errorf("--replica-server-id=%d is already in use on %s or one of its replicas (another gh-ost migration?). Please provide a unique --replica-server-id, such as %d", serverId, this.connectionConfig.Key.String(), suggestedServerId)
+	}
+	this.migrationContext.Log.Infof("replica server id %d validated on %s", serverId, this.connectionConfig.Key.String())
+	return nil
+}
+
+// readLowerCaseTableNames reads lower_case_table_names, which determines whether schema and
+// table names in the binary logs are to be compared case sensitively
+func (this *Inspector) readLowerCaseTableNames() error {
+	var lowerCaseTableNames int
+	query := `select /* gh-ost */ @@global.lower_case_table_names`
+	if err := this.db.QueryRow(query).Scan(&lowerCaseTableNames); err != nil {
+		return err
+	}
+	this.migrationContext.CaseSensitiveTableNames = lowerCaseTableNames == 0
+	this.migrationContext.Log.Debugf("lower_case_table_names=%d on %s", lowerCaseTableNames, this.connectionConfig.Key.String())
+	return nil
+}
+
+// validateBinlogTransactionCompression refuses servers that write compressed transaction payloads
+// to their binary logs, which the binlog reader cannot decode. A replica logs its source's
+// compressed payloads as they are, so this is checked on both the inspected server and the applier,
+// which is the master unless testing or migrating on a replica.
+func validateBinlogTransactionCompression(db *gosql.DB, instanceKey mysql.InstanceKey) error {
+	// binlog_transaction_compression only exists as of MySQL 8.0.20
+	binlogTransactionCompression, err := mysql.GetGlobalVariable(db, "binlog_transaction_compression")
+	if err != nil {
+		return err
+	}
+	if strings.EqualFold(binlogTransactionCompression, "ON") {
+		return fmt.Errorf("%s has binlog_transaction_compression enabled; compressed transaction payloads are not supported. This operation cannot proceed. You may `set global binlog_transaction_compression=OFF` and try again", instanceKey.String())
+	}
+	return nil
+}
+
 // validateLogSlaveUpdates checks that binary log log_slave_updates is set. This test is not required when migrating on replica or when migrating directly on master
 func (this *Inspector) validateLogSlaveUpdates() error {
 	query := `select /* gh-ost */ @@global.log_slave_updates`
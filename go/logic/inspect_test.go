@@ -6,10 +6,13 @@
 package logic
 
 import (
+	"strings"
 	"testing"
 
 	test "github.com/openark/golib/tests"
 
+	"github.com/github/gh-ost/go/base"
+	"github.com/github/gh-ost/go/mysql"
 	"github.com/github/gh-ost/go/sql"
 )
 
@@ -29,3 +32,23 @@ func TestInspectGetSharedUniqueKeys(t *testing.T) {
 	test.S(t).ExpectEquals(sharedUniqKeys[0].Columns.String(), "id,item_id")
 	test.S(t).ExpectEquals(sharedUniqKeys[1].Columns.String(), "id,org_id")
 }
+
+func TestInspectCheckReplicaServerIdCollision(t *testing.T) {
+	migrationContext := base.NewMigrationContext()
+	migrationContext.ReplicaServerId = 99999
+	inspector := &Inspector{
+		connectionConfig: mysql.NewConnectionConfig(),
+		migrationContext: migrationContext,
+	}
+
+	test.S(t).ExpectNil(inspector.checkReplicaServerIdCollision(1, nil))
+	test.S(t).ExpectNil(inspector.checkReplicaServerIdCollision(1, []uint{2, 3}))
+
+	err := inspector.checkReplicaServerIdCollision(99999, []uint{2, 3})
+	test.S(t).ExpectNotNil(err)
+	test.S(t).ExpectTrue(strings.Contains(err.Error(), "such as 100000"))
+
+	err = inspector.checkReplicaServerIdCollision(1, []uint{2, 99999, 100004})
+	test.S(t).ExpectNotNil(err)
+	test.S(t).ExpectTrue(strings.Contains(err.Error(), "such as 100005"))
+}
@@ -10,7 +10,6 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"math"
 	"os"
 	"strings"
 	"sync/atomic"
@@ -155,21 +154,9 @@ func (this *Migrator) retryOperation(operation func() error, notFatalHint ...boo
 // attempts are reached. Wait intervals between attempts obey a maximum of
 // `ExponentialBackoffMaxInterval`.
 func (this *Migrator) retryOperationWithExponentialBackoff(operation func() error, notFatalHint ...bool) (err error) {
-	var interval int64
-	maxRetries := int(this.migrationContext.MaxRetries())
-	maxInterval := this.migrationContext.ExponentialBackoffMaxInterval
-	for i := 0; i < maxRetries; i++ {
-		newInterval := int64(math.Exp2(float64(i - 1)))
-		if newInterval <= maxInterval {
-			interval = newInterval
-		}
-		if i != 0 {
-			time.Sleep(time.Duration(interval) * time.Second)
-		}
-		err = operation()
-		if err == nil {
-			return nil
-		}
+	err = this.migrationContext.RetryWithExponentialBackoff(operation, time.Sleep)
+	if err == nil {
+		return nil
 	}
 	if len(notFatalHint) == 0 {
 		this.migrationContext.PanicAbort <- err
@@ -252,6 +239,46 @@ func (this *Migrator) onChangelogHeartbeatEvent(dmlEvent *binlog.BinlogDMLEvent)
 	}
 }
 
+// waitForGhostTableMigrated blocks until the GhostTableMigrated changelog state is read back from
+// the binary logs. A misnamed or unreplicated changelog table would otherwise block here silently,
+// so we report what the streamer is waiting for every warningInterval. A non-positive interval
+// disables the warning.
+func (this *Migrator) waitForGhostTableMigrated(warningInterval time.Duration) {
+	if warningInterval <= 0 {
+		<-this.ghostTableMigrated
+		return
+	}
+	ticker := time.NewTicker(warningInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-this.ghostTableMigrated:
+			return
+		case <-ticker.C:
+			this.migrationContext.Log.Warningf("Still waiting for changelog state %s on %s.%s in the binary logs of %s; streamer is at %+v. %s",
+				GhostTableMigrated,
+				sql.EscapeName(this.migrationContext.DatabaseName),
+				sql.EscapeName(this.migrationContext.GetChangelogTableName()),
+				this.migrationContext.InspectorConnectionConfig.Key.String(),
+				this.migrationContext.GetRecentBinlogCoordinates(),
+				this.changelogReplicationHint(),
+			)
+		}
+	}
+}
+
+// changelogReplicationHint suggests why the changelog table's events may not be showing up in the
+// inspected server's binary logs, depending on whether that server receives them via replication.
+func (this *Migrator) changelogReplicationHint() string {
+	changelogTable := fmt.Sprintf("%s.%s", sql.EscapeName(this.migrationContext.DatabaseName), sql.EscapeName(this.migrationContext.GetChangelogTableName()))
+	inspectorKey := this.migrationContext.InspectorConnectionConfig.Key
+	applierKey := this.migrationContext.ApplierConnectionConfig.Key
+	if inspectorKey.Equals(&applierKey) {
+		return fmt.Sprintf("%s is written directly on %s, and is not subject to replication filters", changelogTable, inspectorKey.String())
+	}
+	return fmt.Sprintf("%s is written on %s and replicated to %s. Make sure replication is running and that %s is not excluded by replicate-ignore-db, replicate-ignore-table or replicate-wild-ignore-table on %s, nor by binlog-ignore-db on %s", changelogTable, applierKey.String(), inspectorKey.String(), changelogTable, inspectorKey.String(), applierKey.String())
+}
+
 // listenOnPanicAbort aborts on abort request
 func (this *Migrator) listenOnPanicAbort() {
 	err := <-this.migrationContext.PanicAbort
@@ -377,7 +404,7 @@ func (this *Migrator) Migrate() (err error) {
 
 	initialLag, _ := this.inspector.getReplicationLag()
 	this.migrationContext.Log.Infof("Waiting for ghost table to be migrated. Current lag is %+v", initialLag)
-	<-this.ghostTableMigrated
+	this.waitForGhostTableMigrated(time.Duration(this.migrationContext.ChangelogStateWarningIntervalSeconds) * time.Second)
 	this.migrationContext.Log.Debugf("ghost table migrated")
 	// Yay! We now know the Ghost and Changelog tables are good to examine!
 	// When running on replica, this means the replica has those tables. When running
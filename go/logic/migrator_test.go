@@ -7,6 +7,7 @@ package logic
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -19,6 +20,7 @@ import (
 
 	"github.com/github/gh-ost/go/base"
 	"github.com/github/gh-ost/go/binlog"
+	"github.com/github/gh-ost/go/mysql"
 	"github.com/github/gh-ost/go/sql"
 )
 
@@ -111,6 +113,93 @@ func TestMigratorOnChangelogEvent(t *testing.T) {
 	})
 }
 
+// warningCapturingLogger records the messages logged via Warningf
+type warningCapturingLogger struct {
+	base.Logger
+	mutex    sync.Mutex
+	warnings []string
+}
+
+func (this *warningCapturingLogger) Warningf(format string, args ...interface{}) error {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	this.warnings = append(this.warnings, fmt.Sprintf(format, args...))
+	return nil
+}
+
+func (this *warningCapturingLogger) Warnings() []string {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	return append([]string{}, this.warnings...)
+}
+
+func TestMigratorWaitForGhostTableMigrated(t *testing.T) {
+	t.Run("warns while waiting", func(t *testing.T) {
+		logger := &warningCapturingLogger{Logger: base.NewDefaultLogger()}
+		migrationContext := base.NewMigrationContext()
+		migrationContext.Log = logger
+		migrationContext.DatabaseName = "test"
+		migrationContext.OriginalTableName = "tablename"
+		migrationContext.InspectorConnectionConfig.Key = mysql.InstanceKey{Hostname: "replica", Port: 3306}
+		migrationContext.ApplierConnectionConfig.Key = mysql.InstanceKey{Hostname: "primary", Port: 3306}
+		migrator := NewMigrator(migrationContext, "1.2.3")
+
+		done := make(chan struct{})
+		go func() {
+			migrator.waitForGhostTableMigrated(time.Millisecond)
+			close(done)
+		}()
+
+		// let a few warning intervals elapse before the state arrives
+		time.Sleep(10 * time.Millisecond)
+		select {
+		case <-done:
+			t.Fatal("expected waitForGhostTableMigrated to block until the changelog state arrives")
+		default:
+		}
+
+		migrator.ghostTableMigrated <- true
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("expected waitForGhostTableMigrated to return")
+		}
+
+		warnings := logger.Warnings()
+		tests.S(t).ExpectTrue(len(warnings) > 0)
+		tests.S(t).ExpectTrue(strings.Contains(warnings[0], "`test`.`_tablename_ghc`"))
+		tests.S(t).ExpectTrue(strings.Contains(warnings[0], "replicated to replica:3306"))
+	})
+
+	t.Run("hint when inspecting the applier", func(t *testing.T) {
+		migrationContext := base.NewMigrationContext()
+		migrationContext.DatabaseName = "test"
+		migrationContext.OriginalTableName = "tablename"
+		migrationContext.InspectorConnectionConfig.Key = mysql.InstanceKey{Hostname: "primary", Port: 3306}
+		migrationContext.ApplierConnectionConfig.Key = mysql.InstanceKey{Hostname: "primary", Port: 3306}
+		migrator := NewMigrator(migrationContext, "1.2.3")
+
+		tests.S(t).ExpectEquals(migrator.changelogReplicationHint(), "`test`.`_tablename_ghc` is written directly on primary:3306, and is not subject to replication filters")
+	})
+
+	t.Run("no warning when disabled", func(t *testing.T) {
+		logger := &warningCapturingLogger{Logger: base.NewDefaultLogger()}
+		migrationContext := base.NewMigrationContext()
+		migrationContext.Log = logger
+		migrator := NewMigrator(migrationContext, "1.2.3")
+
+		done := make(chan struct{})
+		go func() {
+			migrator.waitForGhostTableMigrated(0)
+			close(done)
+		}()
+		time.Sleep(10 * time.Millisecond)
+		migrator.ghostTableMigrated <- true
+		<-done
+		tests.S(t).ExpectEquals(len(logger.Warnings()), 0)
+	})
+}
+
 func TestMigratorValidateStatement(t *testing.T) {
 	t.Run("add-column", func(t *testing.T) {
 		migrationContext := base.NewMigrationContext()
@@ -8,6 +8,7 @@ package logic
 import (
 	gosql "database/sql"
 	"fmt"
+	"math/rand"
 	"strings"
 	"sync"
 	"time"
@@ -31,6 +32,17 @@ const (
 	ReconnectStreamerSleepSeconds = 5
 )
 
+// ReconnectExhaustedError is returned by StreamEvents when the streamer fails to make progress
+// after MaxRetries() successive reconnect attempts.
+type ReconnectExhaustedError struct {
+	Retries     int64
+	Coordinates *mysql.BinlogCoordinates
+}
+
+func (this *ReconnectExhaustedError) Error() string {
+	return fmt.Sprintf("%d successive failures in streamer reconnect at coordinates %+v", this.Retries, this.Coordinates)
+}
+
 // EventsStreamer reads data from binary logs and streams it on. It acts as a publisher,
 // and interested parties may subscribe for per-table events.
 type EventsStreamer struct {
@@ -43,6 +55,7 @@ type EventsStreamer struct {
 	eventsChannel            chan *binlog.BinlogEntry
 	binlogReader             *binlog.GoMySQLReader
 	name                     string
+	sleep                    func(time.Duration)
 }
 
 func NewEventsStreamer(migrationContext *base.MigrationContext) *EventsStreamer {
@@ -53,6 +66,7 @@ func NewEventsStreamer(migrationContext *base.MigrationContext) *EventsStreamer
 		listenersMutex:   &sync.Mutex{},
 		eventsChannel:    make(chan *binlog.BinlogEntry, EventsChannelBufferSize),
 		name:             "streamer",
+		sleep:            time.Sleep,
 	}
 }
 
@@ -78,20 +92,39 @@ func (this *EventsStreamer) AddListener(
 	return nil
 }
 
-// notifyListeners will notify relevant listeners with given DML event. Only
-// listeners registered for changes on the table on which the DML operates are notified.
-func (this *EventsStreamer) notifyListeners(binlogEvent *binlog.BinlogDMLEvent) {
+// namesEqual compares schema or table names the way the inspected server does: exactly when
+// it runs with lower_case_table_names=0, case insensitively otherwise.
+func (this *EventsStreamer) namesEqual(name, otherName string) bool {
+	if this.migrationContext.CaseSensitiveTableNames {
+		return name == otherName
+	}
+	return strings.EqualFold(name, otherName)
+}
+
+// getListeners returns the listeners registered for changes on the table on which the DML operates
+func (this *EventsStreamer) getListeners(binlogEvent *binlog.BinlogDMLEvent) (listeners []*BinlogEventListener) {
 	this.listenersMutex.Lock()
 	defer this.listenersMutex.Unlock()
 
 	for _, listener := range this.listeners {
-		listener := listener
-		if !strings.EqualFold(listener.databaseName, binlogEvent.DatabaseName) {
+		if !this.namesEqual(listener.databaseName, binlogEvent.DatabaseName) {
 			continue
 		}
-		if !strings.EqualFold(listener.tableName, binlogEvent.TableName) {
+		if !this.namesEqual(listener.tableName, binlogEvent.TableName) {
 			continue
 		}
+		listeners = append(listeners, listener)
+	}
+	return listeners
+}
+
+// notifyListeners will notify relevant listeners with given DML event. Only
+// listeners registered for changes on the table on which the DML operates are notified.
+// Listeners are invoked without holding listenersMutex, so that they may safely call back
+// into the streamer.
+func (this *EventsStreamer) notifyListeners(binlogEvent *binlog.BinlogDMLEvent) {
+	for _, listener := range this.getListeners(binlogEvent) {
+		listener := listener
 		if listener.async {
 			go func() {
 				listener.onDmlEvent(binlogEvent)
@@ -103,27 +136,56 @@ func (this *EventsStreamer) notifyListeners(binlogEvent *binlog.BinlogDMLEvent)
 }
 
 func (this *EventsStreamer) InitDBConnections() (err error) {
-	EventsStreamerUri := this.connectionConfig.GetDBUri(this.migrationContext.DatabaseName)
-	if this.db, _, err = mysql.GetDB(this.migrationContext.Uuid, EventsStreamerUri); err != nil {
-		return err
-	}
-	if _, err := base.ValidateConnection(this.db, this.connectionConfig, this.migrationContext, this.name); err != nil {
+	// Connection failures (e.g. the server is mid-failover) are retried rather than aborting the migration
+	err = this.connectWithRetries(func() (err error) {
+		EventsStreamerUri := this.connectionConfig.GetDBUri(this.migrationContext.DatabaseName)
+		if this.db, _, err = mysql.GetDB(this.migrationContext.Uuid, EventsStreamerUri); err != nil {
+			return err
+		}
+		if _, err := base.ValidateConnection(this.db, this.connectionConfig, this.migrationContext, this.name); err != nil {
+			return err
+		}
+		return this.readCurrentBinlogCoordinates()
+	})
+	if err != nil {
 		return err
 	}
-	if err := this.readCurrentBinlogCoordinates(); err != nil {
+	// Bad coordinates won't get any better by retrying; fail fast
+	if err := binlog.ValidateCoordinates(*this.initialBinlogCoordinates); err != nil {
 		return err
 	}
-	if err := this.initBinlogReader(this.initialBinlogCoordinates); err != nil {
+	err = this.connectWithRetries(func() error {
+		return this.initBinlogReader(this.initialBinlogCoordinates)
+	})
+	if err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// connectWithRetries attempts the given connect function with exponential backoff, see
+// MigrationContext.RetryWithExponentialBackoff
+func (this *EventsStreamer) connectWithRetries(connect func() error) error {
+	return this.migrationContext.RetryWithExponentialBackoff(func() error {
+		err := connect()
+		if err != nil {
+			this.migrationContext.Log.Infof("Failed connecting binlog streamer: %+v", err)
+		}
+		return err
+	}, this.sleep)
+}
+
 // initBinlogReader creates and connects the reader: we hook up to a MySQL server as a replica
 func (this *EventsStreamer) initBinlogReader(binlogCoordinates *mysql.BinlogCoordinates) error {
-	goMySQLReader := binlog.NewGoMySQLReader(this.migrationContext)
+	return this.connectBinlogReader(binlog.NewGoMySQLReader(this.migrationContext), binlogCoordinates)
+}
+
+// connectBinlogReader connects given reader, and upon success makes it the streamer's reader
+func (this *EventsStreamer) connectBinlogReader(goMySQLReader *binlog.GoMySQLReader, binlogCoordinates *mysql.BinlogCoordinates) error {
 	if err := goMySQLReader.ConnectBinlogStreamer(*binlogCoordinates); err != nil {
+		// StartSync may have registered as a replica before failing; release its connection
+		goMySQLReader.Close()
 		return err
 	}
 	this.binlogReader = goMySQLReader
@@ -161,6 +223,42 @@ func (this *EventsStreamer) readCurrentBinlogCoordinates() error {
 	return nil
 }
 
+// reconnectSleepDuration returns how long to wait before reconnecting the streamer. The wait
+// starts at ReconnectStreamerSleepSeconds and doubles with each successive failure, up to
+// maxIntervalSeconds. Up to 20% random jitter is added so that concurrent migrations against
+// the same server do not reconnect in lockstep.
+func reconnectSleepDuration(successiveFailures int64, maxIntervalSeconds int64) time.Duration {
+	intervalSeconds := int64(ReconnectStreamerSleepSeconds)
+	for i := int64(0); i < successiveFailures && intervalSeconds < maxIntervalSeconds; i++ {
+		intervalSeconds *= 2
+	}
+	if intervalSeconds > maxIntervalSeconds && maxIntervalSeconds > ReconnectStreamerSleepSeconds {
+		intervalSeconds = maxIntervalSeconds
+	}
+	interval := time.Duration(intervalSeconds) * time.Second
+	jitter := time.Duration(rand.Int63n(int64(interval) / 5))
+	return interval + jitter
+}
+
+// sleepUnlessStopped sleeps for the given duration, checking canStopStreaming every pollInterval.
+// It returns early, with true, as soon as streaming can stop.
+func sleepUnlessStopped(duration time.Duration, pollInterval time.Duration, canStopStreaming func() bool) bool {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-timer.C:
+			return canStopStreaming()
+		case <-ticker.C:
+			if canStopStreaming() {
+				return true
+			}
+		}
+	}
+}
+
 // StreamEvents will begin streaming events. It will be blocking, so should be
 // executed by a goroutine
 func (this *EventsStreamer) StreamEvents(canStopStreaming func() bool) error {
@@ -185,7 +283,6 @@ func (this *EventsStreamer) StreamEvents(canStopStreaming func() bool) error {
 
 			this.migrationContext.Log.Infof("StreamEvents encountered unexpected error: %+v", err)
 			this.migrationContext.MarkPointOfInterest()
-			time.Sleep(ReconnectStreamerSleepSeconds * time.Second)
 
 			// See if there's retry overflow
 			if this.binlogReader.LastAppliedRowsEventHint.Equals(&lastAppliedRowsEventHint) {
@@ -194,7 +291,10 @@ func (this *EventsStreamer) StreamEvents(canStopStreaming func() bool) error {
 				successiveFailures = 0
 			}
 			if successiveFailures >= this.migrationContext.MaxRetries() {
-				return fmt.Errorf("%d successive failures in streamer reconnect at coordinates %+v", successiveFailures, this.GetReconnectBinlogCoordinates())
+				return &ReconnectExhaustedError{Retries: successiveFailures, Coordinates: this.GetReconnectBinlogCoordinates()}
+			}
+			if sleepUnlessStopped(reconnectSleepDuration(successiveFailures, this.migrationContext.ExponentialBackoffMaxInterval), time.Second, canStopStreaming) {
+				return nil
 			}
 
 			// Reposition at same binlog file.
@@ -0,0 +1,205 @@
+package logic
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/gh-ost/go/base"
+	"github.com/github/gh-ost/go/binlog"
+	"github.com/github/gh-ost/go/mysql"
+	"github.com/go-mysql-org/go-mysql/replication"
+	"github.com/openark/golib/tests"
+)
+
+func TestReconnectSleepDuration(t *testing.T) {
+	expectBetween := func(d time.Duration, seconds int64) {
+		interval := time.Duration(seconds) * time.Second
+		tests.S(t).ExpectTrue(d >= interval)
+		tests.S(t).ExpectTrue(d <= interval+interval/5)
+	}
+
+	t.Run("grows with successive failures", func(t *testing.T) {
+		expectBetween(reconnectSleepDuration(0, 64), 5)
+		expectBetween(reconnectSleepDuration(1, 64), 10)
+		expectBetween(reconnectSleepDuration(2, 64), 20)
+		expectBetween(reconnectSleepDuration(3, 64), 40)
+	})
+
+	t.Run("capped at max interval", func(t *testing.T) {
+		expectBetween(reconnectSleepDuration(4, 64), 64)
+		expectBetween(reconnectSleepDuration(100, 64), 64)
+	})
+
+	t.Run("max interval below base", func(t *testing.T) {
+		expectBetween(reconnectSleepDuration(0, 0), 5)
+		expectBetween(reconnectSleepDuration(10, 2), 5)
+	})
+}
+
+func TestSleepUnlessStopped(t *testing.T) {
+	t.Run("sleeps full duration", func(t *testing.T) {
+		start := time.Now()
+		stopped := sleepUnlessStopped(20*time.Millisecond, time.Millisecond, func() bool { return false })
+		tests.S(t).ExpectFalse(stopped)
+		tests.S(t).ExpectTrue(time.Since(start) >= 20*time.Millisecond)
+	})
+
+	t.Run("interrupted by stop", func(t *testing.T) {
+		var polls int64
+		start := time.Now()
+		stopped := sleepUnlessStopped(time.Hour, time.Millisecond, func() bool {
+			polls++
+			return polls >= 3
+		})
+		tests.S(t).ExpectTrue(stopped)
+		tests.S(t).ExpectTrue(time.Since(start) < time.Second)
+	})
+}
+
+func TestStreamerNotifyListenersCaseSensitivity(t *testing.T) {
+	newStreamer := func(caseSensitive bool) (*EventsStreamer, map[string]int) {
+		migrationContext := base.NewMigrationContext()
+		migrationContext.CaseSensitiveTableNames = caseSensitive
+		streamer := NewEventsStreamer(migrationContext)
+		notified := map[string]int{}
+		for _, tableName := range []string{"Orders", "orders"} {
+			tableName := tableName
+			streamer.AddListener(false, "test", tableName, func(event *binlog.BinlogDMLEvent) error {
+				notified[tableName]++
+				return nil
+			})
+		}
+		return streamer, notified
+	}
+
+	t.Run("case insensitive", func(t *testing.T) {
+		streamer, notified := newStreamer(false)
+		streamer.notifyListeners(binlog.NewBinlogDMLEvent("TEST", "orders", binlog.InsertDML))
+		tests.S(t).ExpectEquals(notified["Orders"], 1)
+		tests.S(t).ExpectEquals(notified["orders"], 1)
+	})
+
+	t.Run("case sensitive", func(t *testing.T) {
+		streamer, notified := newStreamer(true)
+		streamer.notifyListeners(binlog.NewBinlogDMLEvent("test", "orders", binlog.InsertDML))
+		tests.S(t).ExpectEquals(notified["Orders"], 0)
+		tests.S(t).ExpectEquals(notified["orders"], 1)
+
+		streamer.notifyListeners(binlog.NewBinlogDMLEvent("TEST", "orders", binlog.InsertDML))
+		tests.S(t).ExpectEquals(notified["orders"], 1)
+	})
+}
+
+func TestStreamerConnectWithRetries(t *testing.T) {
+	t.Run("succeeds after transient failure", func(t *testing.T) {
+		migrationContext := base.NewMigrationContext()
+		migrationContext.SetDefaultNumRetries(3)
+		migrationContext.SetExponentialBackoffMaxInterval(64)
+		streamer := NewEventsStreamer(migrationContext)
+		slept := []time.Duration{}
+		streamer.sleep = func(d time.Duration) { slept = append(slept, d) }
+		attempts := 0
+		err := streamer.connectWithRetries(func() error {
+			attempts++
+			if attempts == 1 {
+				return errors.New("connection refused")
+			}
+			return nil
+		})
+		tests.S(t).ExpectNil(err)
+		tests.S(t).ExpectEquals(attempts, 2)
+		tests.S(t).ExpectEquals(len(slept), 1)
+		tests.S(t).ExpectEquals(slept[0], time.Second)
+	})
+
+	t.Run("gives up after max retries", func(t *testing.T) {
+		migrationContext := base.NewMigrationContext()
+		migrationContext.SetDefaultNumRetries(1)
+		streamer := NewEventsStreamer(migrationContext)
+		streamer.sleep = func(time.Duration) { t.Fatal("expected no sleep with a single attempt") }
+		attempts := 0
+		err := streamer.connectWithRetries(func() error {
+			attempts++
+			return errors.New("connection refused")
+		})
+		tests.S(t).ExpectNotNil(err)
+		tests.S(t).ExpectEquals(attempts, 1)
+	})
+}
+
+// newUnreachableStreamerContext returns a context whose inspector connection is refused
+func newUnreachableStreamerContext(retries int64) *base.MigrationContext {
+	migrationContext := base.NewMigrationContext()
+	migrationContext.SetDefaultNumRetries(retries)
+	migrationContext.SetExponentialBackoffMaxInterval(64)
+	migrationContext.ReplicaServerId = 99999
+	migrationContext.InspectorConnectionConfig.Key = mysql.InstanceKey{Hostname: "127.0.0.1", Port: 1}
+	migrationContext.InspectorConnectionConfig.User = "gh-ost"
+	return migrationContext
+}
+
+func TestStreamerInitDBConnectionsRetries(t *testing.T) {
+	streamer := NewEventsStreamer(newUnreachableStreamerContext(3))
+	slept := []time.Duration{}
+	streamer.sleep = func(d time.Duration) { slept = append(slept, d) }
+
+	err := streamer.InitDBConnections()
+	tests.S(t).ExpectNotNil(err)
+	// the connection check is retried, with backoff, before giving up
+	tests.S(t).ExpectEquals(len(slept), 2)
+	tests.S(t).ExpectEquals(slept[0], time.Second)
+	tests.S(t).ExpectEquals(slept[1], 2*time.Second)
+	tests.S(t).ExpectTrue(streamer.binlogReader == nil)
+}
+
+func TestStreamerConnectBinlogReaderClosesOnFailure(t *testing.T) {
+	migrationContext := newUnreachableStreamerContext(1)
+	streamer := NewEventsStreamer(migrationContext)
+	reader := binlog.NewGoMySQLReader(migrationContext)
+	coordinates := &mysql.BinlogCoordinates{LogFile: "mysql-bin.000017", LogPos: 4}
+
+	err := streamer.connectBinlogReader(reader, coordinates)
+	tests.S(t).ExpectNotNil(err)
+	tests.S(t).ExpectTrue(streamer.binlogReader == nil)
+
+	// the failed reader was closed, and cannot be used to sync again
+	err = reader.ConnectBinlogStreamer(*coordinates)
+	tests.S(t).ExpectNotNil(err)
+	tests.S(t).ExpectTrue(strings.Contains(err.Error(), replication.ErrSyncClosed.Error()))
+}
+
+func TestReconnectExhaustedError(t *testing.T) {
+	coordinates := &mysql.BinlogCoordinates{LogFile: "mysql-bin.000017", LogPos: 4}
+	var err error = fmt.Errorf("streaming: %w", &ReconnectExhaustedError{Retries: 60, Coordinates: coordinates})
+
+	var reconnectErr *ReconnectExhaustedError
+	tests.S(t).ExpectTrue(errors.As(err, &reconnectErr))
+	tests.S(t).ExpectEquals(reconnectErr.Retries, int64(60))
+	tests.S(t).ExpectTrue(reconnectErr.Coordinates.Equals(coordinates))
+	tests.S(t).ExpectEquals(reconnectErr.Error(), "60 successive failures in streamer reconnect at coordinates mysql-bin.000017:4")
+}
+
+func TestStreamerNotifyListenersReentrant(t *testing.T) {
+	streamer := NewEventsStreamer(base.NewMigrationContext())
+	streamer.AddListener(false, "test", "orders", func(event *binlog.BinlogDMLEvent) error {
+		// Would deadlock were the listener invoked while holding listenersMutex
+		return streamer.AddListener(false, "test", "orders_audit", func(event *binlog.BinlogDMLEvent) error {
+			return nil
+		})
+	})
+
+	done := make(chan struct{})
+	go func() {
+		streamer.notifyListeners(binlog.NewBinlogDMLEvent("test", "orders", binlog.InsertDML))
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("notifyListeners deadlocked on a reentrant listener")
+	}
+	tests.S(t).ExpectEquals(len(streamer.listeners), 2)
+}
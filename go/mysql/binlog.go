@@ -56,15 +56,53 @@ func (this *BinlogCoordinates) IsEmpty() bool {
 	return this.LogFile == ""
 }
 
+// Validate returns an error if these coordinates are not well formed: an empty log file,
+// a log file without a numeric extension, or a negative log position.
+func (this *BinlogCoordinates) Validate() error {
+	if this.IsEmpty() {
+		return fmt.Errorf("Empty log file in binlog coordinates")
+	}
+	if _, _, ok := parseLogFile(this.LogFile); !ok {
+		return fmt.Errorf("Invalid log file %s in binlog coordinates. Expected a numeric extension, e.g. mysql-bin.000017", this.LogFile)
+	}
+	if this.LogPos < 0 {
+		return fmt.Errorf("Negative log position %d in binlog coordinates %s", this.LogPos, this.DisplayString())
+	}
+	return nil
+}
+
+// parseLogFile splits a log file name such as mysql-bin.000017 into its base name
+// and numeric extension. ok is false when the extension is not a number.
+func parseLogFile(logFile string) (baseName string, fileNum int64, ok bool) {
+	dotIndex := strings.LastIndex(logFile, ".")
+	if dotIndex < 0 {
+		return logFile, 0, false
+	}
+	fileNum, err := strconv.ParseInt(logFile[dotIndex+1:], 10, 64)
+	if err != nil {
+		return logFile, 0, false
+	}
+	return logFile[:dotIndex], fileNum, true
+}
+
+// logFileSmallerThan compares two log file names by their numeric extension, so that
+// mysql-bin.999999 sorts before mysql-bin.1000000. Names with different base names, or
+// non-numeric extensions, are compared lexically.
+func logFileSmallerThan(logFile, otherLogFile string) bool {
+	baseName, fileNum, ok := parseLogFile(logFile)
+	otherBaseName, otherFileNum, otherOk := parseLogFile(otherLogFile)
+	if ok && otherOk && baseName == otherBaseName {
+		return fileNum < otherFileNum
+	}
+	return logFile < otherLogFile
+}
+
 // SmallerThan returns true if this coordinate is strictly smaller than the other.
 func (this *BinlogCoordinates) SmallerThan(other *BinlogCoordinates) bool {
-	if this.LogFile < other.LogFile {
-		return true
-	}
-	if this.LogFile == other.LogFile && this.LogPos < other.LogPos {
-		return true
+	if this.LogFile == other.LogFile {
+		return this.LogPos < other.LogPos
 	}
-	return false
+	return logFileSmallerThan(this.LogFile, other.LogFile)
 }
 
 // SmallerThanOrEquals returns true if this coordinate is the same or equal to the other one.
@@ -38,6 +38,53 @@ func TestBinlogCoordinates(t *testing.T) {
 	test.S(t).ExpectTrue(c1.SmallerThanOrEquals(&c3))
 }
 
+func TestBinlogCoordinatesSmallerThanByFileNumber(t *testing.T) {
+	c1 := BinlogCoordinates{LogFile: "mysql-bin.9", LogPos: 5000}
+	c2 := BinlogCoordinates{LogFile: "mysql-bin.10", LogPos: 104}
+	c3 := BinlogCoordinates{LogFile: "mysql-bin.999999", LogPos: 5000}
+	c4 := BinlogCoordinates{LogFile: "mysql-bin.1000000", LogPos: 104}
+
+	test.S(t).ExpectTrue(c1.SmallerThan(&c2))
+	test.S(t).ExpectFalse(c2.SmallerThan(&c1))
+	test.S(t).ExpectTrue(c3.SmallerThan(&c4))
+	test.S(t).ExpectFalse(c4.SmallerThan(&c3))
+	test.S(t).ExpectTrue(c3.SmallerThanOrEquals(&c4))
+	test.S(t).ExpectFalse(c4.SmallerThanOrEquals(&c3))
+
+	// different base names fall back to lexical comparison
+	c5 := BinlogCoordinates{LogFile: "mysql-bin.10", LogPos: 104}
+	c6 := BinlogCoordinates{LogFile: "relay-bin.9", LogPos: 104}
+	test.S(t).ExpectTrue(c5.SmallerThan(&c6))
+	test.S(t).ExpectFalse(c6.SmallerThan(&c5))
+}
+
+func TestBinlogCoordinatesValidate(t *testing.T) {
+	{
+		c := BinlogCoordinates{LogFile: "mysql-bin.000017", LogPos: 104}
+		test.S(t).ExpectNil(c.Validate())
+	}
+	{
+		c := BinlogCoordinates{LogFile: "mysql-bin.000017", LogPos: 0}
+		test.S(t).ExpectNil(c.Validate())
+	}
+	{
+		c := BinlogCoordinates{LogFile: "", LogPos: 104}
+		test.S(t).ExpectNotNil(c.Validate())
+	}
+	{
+		c := BinlogCoordinates{LogFile: "mysql-bin", LogPos: 104}
+		test.S(t).ExpectNotNil(c.Validate())
+	}
+	{
+		c := BinlogCoordinates{LogFile: "mysql-bin.index", LogPos: 104}
+		test.S(t).ExpectNotNil(c.Validate())
+	}
+	{
+		c := BinlogCoordinates{LogFile: "mysql-bin.000017", LogPos: -1}
+		test.S(t).ExpectNotNil(c.Validate())
+	}
+}
+
 func TestBinlogCoordinatesAsKey(t *testing.T) {
 	m := make(map[BinlogCoordinates]bool)
 
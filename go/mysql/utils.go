@@ -168,6 +168,17 @@ func GetSelfBinlogCoordinates(db *gosql.DB) (selfBinlogCoordinates *BinlogCoordi
 	return selfBinlogCoordinates, err
 }
 
+// GetGlobalVariable reads the value of given global variable on given DB, or an empty string
+// if the variable does not exist on this server version
+func GetGlobalVariable(db *gosql.DB, variableName string) (value string, err error) {
+	query := fmt.Sprintf(`show /* gh-ost */ global variables like '%s'`, variableName)
+	err = sqlutils.QueryRowsMap(db, query, func(rowMap sqlutils.RowMap) error {
+		value = rowMap.GetString("Value")
+		return nil
+	})
+	return value, err
+}
+
 // GetInstanceKey reads hostname and port on given DB
 func GetInstanceKey(db *gosql.DB) (instanceKey *InstanceKey, err error) {
 	instanceKey = &InstanceKey{}